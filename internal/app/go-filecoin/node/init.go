@@ -20,9 +20,18 @@ const defaultPeerKeyBits = 2048
 
 // initCfg contains configuration for initializing a node's repo.
 type initCfg struct {
-	peerKey     crypto.PrivKey
-	defaultKey  *crypto2.KeyInfo
-	initImports []*crypto2.KeyInfo
+	peerKey                crypto.PrivKey
+	defaultKey             *crypto2.KeyInfo
+	initImports            []*crypto2.KeyInfo
+	walletBackend          wallet.Backend
+	remoteBackendEndpoint  string
+	remoteBackendToken     string
+	liteMode               bool
+	liteFullNodeURL        string
+	liteFullNodeToken      string
+	importBundlePath       string
+	importBundlePassphrase string
+	keyType                crypto2.SigType
 }
 
 // InitOpt is an option for initialization of a node's repo.
@@ -44,6 +53,18 @@ func DefaultKeyOpt(ki *crypto2.KeyInfo) InitOpt {
 	}
 }
 
+// KeyTypeOpt selects the signature type of the wallet's default
+// account when one isn't supplied via DefaultKeyOpt. Passing
+// crypto2.SigTypeBLS produces a BLS default key, needed for
+// aggregated-signature workflows and for miners that require BLS
+// worker keys; the default, if this option is never given, is
+// secp256k1.
+func KeyTypeOpt(sigType crypto2.SigType) InitOpt {
+	return func(opts *initCfg) {
+		opts.keyType = sigType
+	}
+}
+
 // ImportKeyOpt imports the provided key during initialization.
 func ImportKeyOpt(ki *crypto2.KeyInfo) InitOpt {
 	return func(opts *initCfg) {
@@ -51,37 +72,114 @@ func ImportKeyOpt(ki *crypto2.KeyInfo) InitOpt {
 	}
 }
 
+// ImportBundleOpt imports every key in the encrypted key bundle at
+// path, decrypting it with passphrase (see wallet.ExportBundle for the
+// bundle format), during initialization. The bundle's first key
+// becomes the wallet's default account unless DefaultKeyOpt was also
+// given. Use this to migrate a wallet from another machine without
+// shuttling raw key files around.
+func ImportBundleOpt(path, passphrase string) InitOpt {
+	return func(opts *initCfg) {
+		opts.importBundlePath = path
+		opts.importBundlePassphrase = passphrase
+	}
+}
+
+// BackendOpt sets the wallet backend used to store and sign for the
+// node's keys, in place of the default local, datastore-backed
+// DSBackend. Use this to back a node with a hardware wallet, HSM, or
+// any other implementation of wallet.Backend.
+func BackendOpt(backend wallet.Backend) InitOpt {
+	return func(opts *initCfg) {
+		opts.walletBackend = backend
+	}
+}
+
+// RemoteBackendOpt configures the node's wallet to delegate key storage
+// and signing to an external signer process reachable over JSON-RPC at
+// endpoint, authenticating with token. The node never writes private
+// key material to the local repo; the endpoint and token are recorded
+// in the repo config so that subsequent boots can reconnect
+// automatically without RemoteBackendOpt being passed again.
+func RemoteBackendOpt(endpoint, token string) InitOpt {
+	return func(opts *initCfg) {
+		opts.remoteBackendEndpoint = endpoint
+		opts.remoteBackendToken = token
+	}
+}
+
+// LiteModeOpt configures Init to skip local chain initialization
+// entirely: no genesis is imported and chain.Init is never run. Instead
+// the repo records fullNodeURL (and an auth token, if the full node
+// requires one) so that, at runtime, all chain and state queries are
+// proxied to that trusted full node. Use this for low-resource clients
+// such as CLI wallets and dashboards that don't want to hold the full
+// state tree.
+func LiteModeOpt(fullNodeURL, token string) InitOpt {
+	return func(opts *initCfg) {
+		opts.liteMode = true
+		opts.liteFullNodeURL = fullNodeURL
+		opts.liteFullNodeToken = token
+	}
+}
+
 // Init initializes a Filecoin repo with genesis state and keys.
 // This will always set the configuration for wallet default address (to the specified default
 // key or a newly generated one), but otherwise leave the repo's config object intact.
 // Make further configuration changes after initialization.
 func Init(ctx context.Context, r repo.Repo, gen consensus.GenesisInitFunc, opts ...InitOpt) error {
-	cfg := new(initCfg)
+	cfg := &initCfg{keyType: crypto2.SigTypeSecp256k1}
 	for _, o := range opts {
 		o(cfg)
 	}
 
-	bs := bstore.NewBlockstore(r.Datastore())
-	cst := cborutil.NewIpldStore(bs)
-	if _, err := chain.Init(ctx, r, bs, cst, gen); err != nil {
-		return errors.Wrap(err, "Could not Init Node")
+	if cfg.liteMode {
+		r.Config().Net.LiteMode = true
+		r.Config().Net.FullNodeURL = cfg.liteFullNodeURL
+		r.Config().Net.FullNodeToken = cfg.liteFullNodeToken
+	} else {
+		bs := bstore.NewBlockstore(r.Datastore())
+		cst := cborutil.NewIpldStore(bs)
+		if _, err := chain.Init(ctx, r, bs, cst, gen); err != nil {
+			return errors.Wrap(err, "Could not Init Node")
+		}
 	}
 
 	if err := initPeerKey(r.Keystore(), cfg.peerKey); err != nil {
 		return err
 	}
 
-	backend, err := wallet.NewDSBackend(r.WalletDatastore())
+	backend, err := initWalletBackend(r, cfg)
 	if err != nil {
-		return errors.Wrap(err, "failed to open wallet datastore")
+		return err
 	}
 	w := wallet.New(backend)
 
-	defaultKey, err := initDefaultKey(w, cfg.defaultKey)
-	if err != nil {
-		return err
+	var bundleDefaultKey *crypto2.KeyInfo
+	if cfg.importBundlePath != "" {
+		bundleKeys, err := wallet.ImportBundle(cfg.importBundlePath, cfg.importBundlePassphrase)
+		if err != nil {
+			return errors.Wrap(err, "failed to read key bundle")
+		}
+		if err := importInitKeys(ctx, w, bundleKeys); err != nil {
+			return errors.Wrap(err, "failed to import key bundle")
+		}
+		if len(bundleKeys) > 0 {
+			bundleDefaultKey = bundleKeys[0]
+		}
+	}
+
+	var defaultKey *crypto2.KeyInfo
+	if cfg.defaultKey == nil && bundleDefaultKey != nil {
+		// Already imported above; just adopt it as the default.
+		defaultKey = bundleDefaultKey
+	} else {
+		defaultKey, err = initDefaultKey(ctx, w, cfg.defaultKey, cfg.keyType)
+		if err != nil {
+			return err
+		}
 	}
-	err = importInitKeys(w, cfg.initImports)
+	err = importInitKeys(ctx, w, cfg.initImports)
 	if err != nil {
 		return err
 	}
@@ -98,6 +196,38 @@ func Init(ctx context.Context, r repo.Repo, gen consensus.GenesisInitFunc, opts
 	return nil
 }
 
+// initWalletBackend picks the wallet.Backend to use for this repo: an
+// explicit backend passed via BackendOpt, a RemoteBackend built from
+// RemoteBackendOpt, or by default a local DSBackend backed by the
+// repo's wallet datastore. A remote backend's endpoint and token are
+// recorded in the repo config so that subsequent boots reconnect
+// automatically without the caller having to pass RemoteBackendOpt
+// again.
+func initWalletBackend(r repo.Repo, cfg *initCfg) (wallet.Backend, error) {
+	if cfg.walletBackend != nil {
+		return cfg.walletBackend, nil
+	}
+
+	if cfg.remoteBackendEndpoint != "" {
+		remote, err := wallet.NewRemoteBackend(cfg.remoteBackendEndpoint, cfg.remoteBackendToken)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to remote wallet backend")
+		}
+		r.Config().Wallet.RemoteBackendURL = cfg.remoteBackendEndpoint
+		r.Config().Wallet.RemoteBackendToken = cfg.remoteBackendToken
+		if err := r.ReplaceConfig(r.Config()); err != nil {
+			return nil, errors.Wrap(err, "failed to record remote wallet backend")
+		}
+		return remote, nil
+	}
+
+	backend, err := wallet.NewDSBackend(r.WalletDatastore())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open wallet datastore")
+	}
+	return backend, nil
+}
+
 func initPeerKey(store keystore.Keystore, key crypto.PrivKey) error {
 	var err error
 	if key == nil {
@@ -112,24 +242,29 @@ func initPeerKey(store keystore.Keystore, key crypto.PrivKey) error {
 	return nil
 }
 
-func initDefaultKey(w *wallet.Wallet, key *crypto2.KeyInfo) (*crypto2.KeyInfo, error) {
+// initDefaultKey establishes the wallet's default account. When key is
+// nil, the backend generates a new one of the given signature type;
+// backends that cannot export private key material (e.g. a
+// RemoteBackend) hand back a KeyInfo whose address is known locally
+// even though the key itself lives elsewhere.
+func initDefaultKey(ctx context.Context, w *wallet.Wallet, key *crypto2.KeyInfo, sigType crypto2.SigType) (*crypto2.KeyInfo, error) {
 	var err error
 	if key == nil {
-		key, err = w.NewKeyInfo()
+		key, err = w.NewKeyInfo(ctx, sigType)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create default key")
 		}
 	} else {
-		if _, err := w.Import(key); err != nil {
+		if _, err := w.Import(ctx, key); err != nil {
 			return nil, errors.Wrap(err, "failed to import default key")
 		}
 	}
 	return key, nil
 }
 
-func importInitKeys(w *wallet.Wallet, importKeys []*crypto2.KeyInfo) error {
+func importInitKeys(ctx context.Context, w *wallet.Wallet, importKeys []*crypto2.KeyInfo) error {
 	for _, ki := range importKeys {
-		_, err := w.Import(ki)
+		_, err := w.Import(ctx, ki)
 		if err != nil {
 			return err
 		}
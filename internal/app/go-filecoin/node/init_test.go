@@ -0,0 +1,231 @@
+package node
+
+import (
+	"context"
+	"encoding/base32"
+	"path/filepath"
+	"testing"
+
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/consensus"
+	crypto2 "github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/repo"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/wallet"
+)
+
+// addressSigType recovers the signature-type protocol byte NewFromBytes
+// tagged addr with, so tests can tell a secp256k1 default key apart from
+// a BLS one without a second exported accessor on address.Address.
+func addressSigType(t *testing.T, addr address.Address) byte {
+	t.Helper()
+	raw, err := base32.StdEncoding.DecodeString(addr.String())
+	require.NoError(t, err)
+	require.NotEmpty(t, raw)
+	return raw[0]
+}
+
+func TestInitDefaultsToSecp256k1Key(t *testing.T) {
+	require := require.New(t)
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis))
+
+	addr := r.Config().Wallet.DefaultAddress
+	require.False(addr.Empty())
+	require.EqualValues(1, addressSigType(t, addr)) // protocolSecp256k1
+}
+
+func TestInitKeyTypeOptSelectsBLS(t *testing.T) {
+	require := require.New(t)
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis, KeyTypeOpt(crypto2.SigTypeBLS)))
+
+	addr := r.Config().Wallet.DefaultAddress
+	require.False(addr.Empty())
+	require.EqualValues(3, addressSigType(t, addr)) // protocolBLS
+}
+
+func TestInitDefaultKeyOptOverridesGeneratedKey(t *testing.T) {
+	require := require.New(t)
+
+	ki, err := crypto2.NewKeyInfo(crypto2.SigTypeSecp256k1)
+	require.NoError(err)
+	wantAddr, err := ki.Address()
+	require.NoError(err)
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis, DefaultKeyOpt(ki)))
+
+	require.Equal(wantAddr, r.Config().Wallet.DefaultAddress)
+}
+
+func TestInitImportKeyOptStoresKeyInBackend(t *testing.T) {
+	require := require.New(t)
+
+	ki, err := crypto2.NewKeyInfo(crypto2.SigTypeSecp256k1)
+	require.NoError(err)
+	addr, err := ki.Address()
+	require.NoError(err)
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis, ImportKeyOpt(ki)))
+
+	backend, err := wallet.NewDSBackend(r.WalletDatastore())
+	require.NoError(err)
+	require.True(backend.HasKey(context.Background(), addr))
+}
+
+func TestInitImportBundleOptAdoptsFirstKeyAsDefault(t *testing.T) {
+	require := require.New(t)
+
+	k1, err := crypto2.NewKeyInfo(crypto2.SigTypeSecp256k1)
+	require.NoError(err)
+	k2, err := crypto2.NewKeyInfo(crypto2.SigTypeSecp256k1)
+	require.NoError(err)
+	wantAddr, err := k1.Address()
+	require.NoError(err)
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(wallet.ExportBundle(path, []*crypto2.KeyInfo{k1, k2}, "correct horse battery staple"))
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis, ImportBundleOpt(path, "correct horse battery staple")))
+
+	require.Equal(wantAddr, r.Config().Wallet.DefaultAddress)
+}
+
+func TestInitDefaultKeyOptTakesPrecedenceOverBundle(t *testing.T) {
+	require := require.New(t)
+
+	bundleKey, err := crypto2.NewKeyInfo(crypto2.SigTypeSecp256k1)
+	require.NoError(err)
+	explicitKey, err := crypto2.NewKeyInfo(crypto2.SigTypeSecp256k1)
+	require.NoError(err)
+	wantAddr, err := explicitKey.Address()
+	require.NoError(err)
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(wallet.ExportBundle(path, []*crypto2.KeyInfo{bundleKey}, "correct horse battery staple"))
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis,
+		ImportBundleOpt(path, "correct horse battery staple"),
+		DefaultKeyOpt(explicitKey),
+	))
+
+	require.Equal(wantAddr, r.Config().Wallet.DefaultAddress, "DefaultKeyOpt must win over the bundle's first key")
+}
+
+func TestInitBackendOptUsesProvidedBackend(t *testing.T) {
+	require := require.New(t)
+
+	backend := newFakeBackend()
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis, BackendOpt(backend)))
+
+	require.Len(backend.keys, 1, "Init should have generated the default key through the provided backend")
+	require.Equal(r.Config().Wallet.DefaultAddress, backend.keys[0])
+}
+
+func TestInitLiteModeOptSkipsChainInitAndRecordsFullNode(t *testing.T) {
+	require := require.New(t)
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis, LiteModeOpt("https://full-node.example", "a-token")))
+
+	require.True(r.Config().Net.LiteMode)
+	require.Equal("https://full-node.example", r.Config().Net.FullNodeURL)
+	require.Equal("a-token", r.Config().Net.FullNodeToken)
+	require.False(r.Config().Wallet.DefaultAddress.Empty(), "wallet init still runs in lite mode")
+}
+
+func TestInitPeerKeyOptStoresGivenKey(t *testing.T) {
+	require := require.New(t)
+
+	peerKey, _, err := libp2pcrypto.GenerateKeyPair(libp2pcrypto.RSA, defaultPeerKeyBits)
+	require.NoError(err)
+
+	r := repo.NewInMemoryRepo()
+	require.NoError(Init(context.Background(), r, consensus.DefaultGenesis, PeerKeyOpt(peerKey)))
+
+	got, err := r.Keystore().Get("self")
+	require.NoError(err)
+	require.True(peerKey.Equals(got))
+}
+
+// TestInitWalletBackendPersistsRemoteCredentials exercises
+// initWalletBackend directly, rather than the full Init, because the
+// returned RemoteBackend would otherwise dial its endpoint for real the
+// first time a default key is generated.
+func TestInitWalletBackendPersistsRemoteCredentials(t *testing.T) {
+	require := require.New(t)
+
+	r := repo.NewInMemoryRepo()
+	cfg := &initCfg{
+		remoteBackendEndpoint: "https://signer.example",
+		remoteBackendToken:    "a-token",
+	}
+
+	backend, err := initWalletBackend(r, cfg)
+	require.NoError(err)
+	_, ok := backend.(*wallet.RemoteBackend)
+	require.True(ok, "expected a *wallet.RemoteBackend")
+
+	require.Equal("https://signer.example", r.Config().Wallet.RemoteBackendURL)
+	require.Equal("a-token", r.Config().Wallet.RemoteBackendToken)
+}
+
+// fakeBackend is a minimal wallet.Backend used to confirm BackendOpt's
+// backend is the one Init actually drives, without exercising a real
+// DSBackend or dialing a real RemoteBackend.
+type fakeBackend struct {
+	keys []address.Address
+}
+
+var _ wallet.Backend = (*fakeBackend)(nil)
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{}
+}
+
+func (b *fakeBackend) Import(ctx context.Context, ki *crypto2.KeyInfo) (address.Address, error) {
+	addr, err := ki.Address()
+	if err != nil {
+		return address.Undef, err
+	}
+	b.keys = append(b.keys, addr)
+	return addr, nil
+}
+
+func (b *fakeBackend) List(ctx context.Context) ([]address.Address, error) {
+	return b.keys, nil
+}
+
+func (b *fakeBackend) HasKey(ctx context.Context, addr address.Address) bool {
+	for _, a := range b.keys {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *fakeBackend) Sign(ctx context.Context, addr address.Address, data []byte) (crypto2.Signature, error) {
+	return crypto2.Signature{}, nil
+}
+
+func (b *fakeBackend) NewKeyInfo(ctx context.Context, sigType crypto2.SigType) (*crypto2.KeyInfo, error) {
+	ki, err := crypto2.NewKeyInfo(sigType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := b.Import(ctx, ki); err != nil {
+		return nil, err
+	}
+	return ki, nil
+}
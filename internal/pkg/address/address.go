@@ -0,0 +1,63 @@
+package address
+
+import (
+	"encoding/base32"
+
+	"github.com/pkg/errors"
+)
+
+// Address is an opaque account identifier derived from a public key or
+// actor ID.
+type Address struct {
+	str string
+}
+
+// Undef is the zero-value, invalid Address.
+var Undef = Address{}
+
+// String returns addr's string encoding.
+func (addr Address) String() string {
+	return addr.str
+}
+
+// Empty returns whether addr is the zero-value, invalid Address.
+func (addr Address) Empty() bool {
+	return addr == Undef
+}
+
+// NewFromString parses an address previously produced by String.
+func NewFromString(s string) (Address, error) {
+	if s == "" {
+		return Undef, errors.New("empty address string")
+	}
+	return Address{str: s}, nil
+}
+
+// Protocol byte values prefixed onto the encoded address, one per
+// signature scheme, so that two keys with identical raw bytes but
+// different SigTypes never collide on the same address.
+const (
+	protocolSecp256k1 byte = 1
+	protocolBLS       byte = 3
+)
+
+// protocolForSigType maps a crypto.SigType to its address protocol
+// byte. sigType is passed as an int rather than crypto.SigType to avoid
+// an import cycle between address and crypto.
+func protocolForSigType(sigType int) byte {
+	if sigType == 1 {
+		return protocolBLS
+	}
+	return protocolSecp256k1
+}
+
+// NewFromBytes derives an Address from a key's raw bytes, prefixed with
+// a protocol byte selected by sigType so that secp256k1 and BLS keys
+// sharing the same raw bytes produce distinct addresses.
+func NewFromBytes(sigType int, key []byte) (Address, error) {
+	if len(key) == 0 {
+		return Undef, errors.New("cannot derive address: empty key")
+	}
+	tagged := append([]byte{protocolForSigType(sigType)}, key...)
+	return Address{str: base32.StdEncoding.EncodeToString(tagged)}, nil
+}
@@ -0,0 +1,197 @@
+// Package gen provides a deterministic, in-memory chain generator for
+// tests that need real tipsets and state roots without running a full
+// miner against a live devnet.
+package gen
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/cborutil"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/chain"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/consensus"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/repo"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/wallet"
+)
+
+// ChainGen builds synthetic tipsets on top of a genesis, threading
+// blocks through the same chain.Init and block-store code paths the
+// node uses in production, so the state roots it produces are exactly
+// what production code would compute. It owns its own in-memory repo
+// and wallet, so tests can construct one and advance the chain round
+// by round without a running miner.
+//
+// ChainGen's ticket/election path is mocked: the first account is
+// always the round's winning miner. It is meant for exercising message
+// processing and chain-following code, not for testing consensus
+// itself.
+type ChainGen struct {
+	r   repo.Repo
+	bs  bstore.Blockstore
+	cst *cborutil.IpldStore
+
+	processor consensus.Processor
+	vms       vm.StorageMap
+
+	w        *wallet.Wallet
+	accounts []address.Address
+
+	round uint64
+	head  block.TipSet
+}
+
+// NewChainGen creates a ChainGen whose genesis is produced by genFunc,
+// with numAccounts wallet accounts available via Accounts(). The
+// accounts are only added to ChainGen's wallet: NewChainGen gives them
+// no balance, and the genesis state is exactly what genFunc produces.
+func NewChainGen(genFunc consensus.GenesisInitFunc, numAccounts int) (*ChainGen, error) {
+	if numAccounts < 1 {
+		return nil, errors.Errorf("numAccounts must be at least 1, got %d", numAccounts)
+	}
+
+	r := repo.NewInMemoryRepo()
+	bs := bstore.NewBlockstore(r.Datastore())
+	cst := cborutil.NewIpldStore(bs)
+
+	backend, err := wallet.NewDSBackend(r.WalletDatastore())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open wallet datastore")
+	}
+	w := wallet.New(backend)
+
+	accounts := make([]address.Address, numAccounts)
+	for i := range accounts {
+		// Derive each account's key from its index rather than from a
+		// random source, so every run has the same accounts and hence
+		// the same miner address in every block.
+		seed := sha256.Sum256([]byte(fmt.Sprintf("chaingen-account-%d", i)))
+		ki := &crypto.KeyInfo{SigType: crypto.SigTypeSecp256k1, PrivateKey: seed[:]}
+		addr, err := w.Import(context.Background(), ki)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create account key")
+		}
+		accounts[i] = addr
+	}
+
+	genTS, err := chain.Init(context.Background(), r, bs, cst, genFunc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init genesis")
+	}
+
+	return &ChainGen{
+		r:         r,
+		bs:        bs,
+		cst:       cst,
+		processor: consensus.NewDefaultProcessor(),
+		vms:       vm.NewStorageMap(bs),
+		w:         w,
+		accounts:  accounts,
+		head:      genTS,
+	}, nil
+}
+
+// Accounts returns the addresses of this ChainGen's wallet accounts, in
+// the order they were created.
+func (cg *ChainGen) Accounts() []address.Address {
+	return cg.accounts
+}
+
+// Head returns the tipset at the front of the chain ChainGen has
+// generated so far.
+func (cg *ChainGen) Head() block.TipSet {
+	return cg.head
+}
+
+// NextTipSet mines a single tipset on top of the current head carrying
+// msgs, and advances the head to it.
+func (cg *ChainGen) NextTipSet(msgs []*types.SignedMessage) (block.TipSet, error) {
+	ts, err := cg.mineOn(cg.head, msgs)
+	if err != nil {
+		return block.TipSet{}, err
+	}
+	cg.head = ts
+	return ts, nil
+}
+
+// NextTipSetFrom mines a tipset carrying msgs on top of base rather
+// than the current head, without advancing ChainGen's own head. Use it
+// to fork a test chain off an earlier point without disturbing the
+// main line the ChainGen otherwise extends.
+func (cg *ChainGen) NextTipSetFrom(base block.TipSet, msgs []*types.SignedMessage) (block.TipSet, error) {
+	return cg.mineOn(base, msgs)
+}
+
+// mineOn assembles and stores a single-block tipset on top of base
+// carrying msgs, running msgs through the same consensus.Processor the
+// node uses in production so the resulting state root is byte-identical
+// to what production code would compute for the same inputs. The
+// ticket and election are mocked: the round's miner is always
+// accounts[0].
+func (cg *ChainGen) mineOn(base block.TipSet, msgs []*types.SignedMessage) (block.TipSet, error) {
+	ctx := context.Background()
+	cg.round++
+
+	miner := cg.accounts[0]
+	ticket := cg.mockTicket(base, cg.round)
+
+	height, err := base.Height()
+	if err != nil {
+		return block.TipSet{}, errors.Wrap(err, "failed to read parent height")
+	}
+	parentWeight, err := base.ParentWeight()
+	if err != nil {
+		return block.TipSet{}, errors.Wrap(err, "failed to read parent weight")
+	}
+	parentRoot, err := base.ParentState()
+	if err != nil {
+		return block.TipSet{}, errors.Wrap(err, "failed to read parent state root")
+	}
+
+	newRoot, err := cg.processor.ApplyMessages(ctx, cg.cst, cg.vms, parentRoot, msgs, miner, height+1)
+	if err != nil {
+		return block.TipSet{}, errors.Wrap(err, "failed to apply messages")
+	}
+
+	msgsCid, err := cg.cst.Put(ctx, types.NewSignedMessageCollection(msgs))
+	if err != nil {
+		return block.TipSet{}, errors.Wrap(err, "failed to store block messages")
+	}
+
+	blk := &block.Block{
+		Miner:        miner,
+		Ticket:       ticket,
+		Parents:      base.Key(),
+		ParentWeight: parentWeight,
+		Height:       height + 1,
+		Messages:     msgsCid,
+		StateRoot:    newRoot,
+	}
+
+	if _, err := cg.cst.Put(ctx, blk); err != nil {
+		return block.TipSet{}, errors.Wrap(err, "failed to store block")
+	}
+
+	return block.NewTipSet(blk)
+}
+
+// mockTicket derives a deterministic, reproducible ticket from the
+// parent tipset and round number instead of running a VRF-backed
+// election, so repeated runs of the same test produce the same chain.
+func (cg *ChainGen) mockTicket(base block.TipSet, round uint64) block.Ticket {
+	return block.TicketFromBytes(cborutil.MustDigest(base.Key(), round))
+}
+
+// Export serializes the chain ChainGen has generated, from genesis to
+// its current head, as a CAR file written to w.
+func (cg *ChainGen) Export(ctx context.Context, w cborutil.CarWriter) error {
+	return cborutil.ExportCar(ctx, cg.bs, w, cg.head.Key())
+}
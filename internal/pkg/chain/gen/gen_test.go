@@ -0,0 +1,37 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/consensus"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+)
+
+func TestChainGenNextTipSet(t *testing.T) {
+	require := require.New(t)
+
+	cg, err := NewChainGen(consensus.DefaultGenesis, 3)
+	require.NoError(err)
+	require.Len(cg.Accounts(), 3)
+
+	genesis := cg.Head()
+
+	ts1, err := cg.NextTipSet(nil)
+	require.NoError(err)
+	require.NotEqual(genesis.Key(), ts1.Key())
+	require.Equal(ts1.Key(), cg.Head().Key())
+
+	fork, err := cg.NextTipSetFrom(genesis, []*types.SignedMessage{})
+	require.NoError(err)
+	require.NotEqual(ts1.Key(), fork.Key(), "forked tipset should diverge from the main line")
+	require.Equal(ts1.Key(), cg.Head().Key(), "NextTipSetFrom must not move ChainGen's own head")
+}
+
+func TestNewChainGenRequiresAnAccount(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewChainGen(consensus.DefaultGenesis, 0)
+	require.Error(err)
+}
@@ -0,0 +1,130 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+)
+
+// SigType identifies the signature scheme a key uses.
+type SigType int
+
+const (
+	// SigTypeSecp256k1 is the default signature scheme for account keys.
+	SigTypeSecp256k1 SigType = iota
+	// SigTypeBLS identifies a BLS key, used for aggregated signatures
+	// and by miners that require BLS worker keys.
+	SigTypeBLS
+)
+
+// Signature is the result of signing data with a KeyInfo.
+type Signature struct {
+	Type SigType
+	Data []byte
+}
+
+// KeyInfo holds the material for a single wallet key: its signature
+// type and, when known locally, its private key bytes. A KeyInfo
+// produced by a backend that cannot export private key material (see
+// NewAddressOnlyKeyInfo) carries only the resulting address.
+type KeyInfo struct {
+	SigType    SigType
+	PrivateKey []byte
+
+	// addr is set directly for KeyInfos whose private key is not held
+	// locally; Address() returns it without attempting to derive an
+	// address from PrivateKey.
+	addr *address.Address
+}
+
+// NewKeyInfo generates a new private key of sigType and wraps it in a
+// KeyInfo.
+func NewKeyInfo(sigType SigType) (*KeyInfo, error) {
+	// The concrete key generation for each SigType lives with the
+	// signature scheme implementation; 32 random bytes stand in here
+	// for whichever curve/scheme sigType selects.
+	priv := make([]byte, 32)
+	if _, err := rand.Read(priv); err != nil {
+		return nil, errors.Wrap(err, "failed to generate private key")
+	}
+	return &KeyInfo{SigType: sigType, PrivateKey: priv}, nil
+}
+
+// keyInfoJSON is KeyInfo's on-the-wire representation. addr is
+// unexported on KeyInfo itself, so encoding/json would otherwise drop
+// it silently for an address-only KeyInfo (see NewAddressOnlyKeyInfo),
+// losing the only thing such a KeyInfo carries.
+type keyInfoJSON struct {
+	SigType    SigType
+	PrivateKey []byte
+	Addr       string `json:"addr,omitempty"`
+}
+
+// MarshalJSON encodes ki, including its address when ki holds no
+// private key material locally.
+func (ki *KeyInfo) MarshalJSON() ([]byte, error) {
+	var addr string
+	if ki.addr != nil {
+		addr = ki.addr.String()
+	}
+	return json.Marshal(keyInfoJSON{
+		SigType:    ki.SigType,
+		PrivateKey: ki.PrivateKey,
+		Addr:       addr,
+	})
+}
+
+// UnmarshalJSON decodes ki from data produced by MarshalJSON.
+func (ki *KeyInfo) UnmarshalJSON(data []byte) error {
+	var kij keyInfoJSON
+	if err := json.Unmarshal(data, &kij); err != nil {
+		return err
+	}
+	ki.SigType = kij.SigType
+	ki.PrivateKey = kij.PrivateKey
+	if kij.Addr != "" {
+		addr, err := address.NewFromString(kij.Addr)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode key address")
+		}
+		ki.addr = &addr
+	}
+	return nil
+}
+
+// NewAddressOnlyKeyInfo returns a KeyInfo for addr with no private key
+// material. It is used by wallet backends that cannot export the keys
+// they hold, such as RemoteBackend: the backend retains the private
+// key, and the local repo only ever learns the resulting address.
+func NewAddressOnlyKeyInfo(addr address.Address) (*KeyInfo, error) {
+	return &KeyInfo{addr: &addr}, nil
+}
+
+// Address derives the address for ki's key, or, for a KeyInfo produced
+// by NewAddressOnlyKeyInfo, returns the address recorded at creation.
+func (ki *KeyInfo) Address() (address.Address, error) {
+	if ki.addr != nil {
+		return *ki.addr, nil
+	}
+	return address.NewFromBytes(int(ki.SigType), ki.PrivateKey)
+}
+
+// Key returns ki's private key bytes, or nil if ki does not hold key
+// material locally (see NewAddressOnlyKeyInfo).
+func (ki *KeyInfo) Key() []byte {
+	return ki.PrivateKey
+}
+
+// Sign signs data with ki's private key. It returns an error if ki
+// does not hold key material locally.
+func (ki *KeyInfo) Sign(data []byte) (Signature, error) {
+	if len(ki.PrivateKey) == 0 {
+		return Signature{}, errors.New("cannot sign: key material is not held locally")
+	}
+	// The concrete signing operation for each SigType lives with the
+	// signature scheme implementation.
+	return Signature{Type: ki.SigType, Data: data}, nil
+}
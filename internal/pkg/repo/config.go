@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+)
+
+// Config is the on-disk, persisted configuration for a node's repo.
+type Config struct {
+	Wallet *WalletConfig
+	Net    *NetworkConfig
+}
+
+// WalletConfig holds the wallet-related portion of a repo's config.
+type WalletConfig struct {
+	// DefaultAddress is the address Init selected (or was given) as the
+	// wallet's default account.
+	DefaultAddress address.Address
+
+	// RemoteBackendURL is the JSON-RPC endpoint of the remote signer
+	// backing this wallet, set when the node was initialized with
+	// RemoteBackendOpt. Empty when the wallet uses a local backend.
+	RemoteBackendURL string
+
+	// RemoteBackendToken authenticates requests to RemoteBackendURL.
+	// Persisted alongside the endpoint so the node can reconnect to the
+	// remote signer on a later boot without RemoteBackendOpt being
+	// passed again.
+	RemoteBackendToken string
+}
+
+// NetworkConfig holds the network-participation portion of a repo's
+// config.
+type NetworkConfig struct {
+	// LiteMode is true for a repo initialized with LiteModeOpt: it
+	// holds no local chain state, and chain/state queries are proxied
+	// to FullNodeURL instead.
+	LiteMode bool
+
+	// FullNodeURL is the trusted full node a lite-mode repo proxies
+	// chain and state queries to. Empty when LiteMode is false.
+	FullNodeURL string
+
+	// FullNodeToken authenticates requests to FullNodeURL, if the full
+	// node requires one.
+	FullNodeToken string
+}
+
+// NewConfig returns a Config with every section initialized to its
+// zero value, ready for a fresh repo.
+func NewConfig() *Config {
+	return &Config{
+		Wallet: new(WalletConfig),
+		Net:    new(NetworkConfig),
+	}
+}
@@ -0,0 +1,35 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+)
+
+// Backend is the interface a wallet key-management implementation must
+// satisfy in order to back a Wallet. DSBackend (local, datastore-backed
+// keys) and RemoteBackend (keys held by an external signer process) both
+// implement it. Every method takes a context so that a caller on the
+// signing path (e.g. block or message signing) can bound how long it
+// waits on a backend, such as a hung or network-partitioned
+// RemoteBackend.
+type Backend interface {
+	// Import adds a key to the backend, returning its address.
+	Import(ctx context.Context, ki *crypto.KeyInfo) (address.Address, error)
+
+	// List returns the addresses of every key the backend knows about.
+	List(ctx context.Context) ([]address.Address, error)
+
+	// HasKey returns whether the backend holds key material for addr.
+	HasKey(ctx context.Context, addr address.Address) bool
+
+	// Sign signs data with the key for addr.
+	Sign(ctx context.Context, addr address.Address, data []byte) (crypto.Signature, error)
+
+	// NewKeyInfo generates a new key of the given signature type and
+	// returns it. Backends that cannot export private key material
+	// (e.g. RemoteBackend) return a KeyInfo whose address is populated
+	// but whose private key bytes are empty.
+	NewKeyInfo(ctx context.Context, sigType crypto.SigType) (*crypto.KeyInfo, error)
+}
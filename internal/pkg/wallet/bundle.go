@@ -0,0 +1,144 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+)
+
+// bundleVersion is the only bundleHeader.V this package knows how to
+// read or write. Bump it, and add a migration in ImportBundle, if the
+// on-disk format ever needs to change.
+const bundleVersion = 1
+
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+)
+
+// bundleHeader is the JSON envelope written ahead of the AES-GCM
+// ciphertext in a key bundle file. It carries everything needed to
+// re-derive the encryption key from a passphrase and decrypt the
+// payload.
+type bundleHeader struct {
+	V     int    `json:"v"`
+	KDF   string `json:"kdf"`
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+}
+
+// bundleFile is the full on-disk representation of a key bundle: the
+// header followed by the AES-GCM-sealed ciphertext of a JSON-encoded
+// []*crypto.KeyInfo.
+type bundleFile struct {
+	bundleHeader
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// ExportBundle encrypts keys with a key derived from passphrase via
+// scrypt, and writes the result to path as a portable key bundle that
+// ImportBundleOpt can later read back on another machine.
+func ExportBundle(path string, keys []*crypto.KeyInfo, passphrase string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return errors.Wrap(err, "failed to generate salt")
+	}
+
+	block, err := newBundleCipher(passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, block.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+
+	plaintext, err := json.Marshal(keys)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode keys")
+	}
+
+	bf := bundleFile{
+		bundleHeader: bundleHeader{
+			V:     bundleVersion,
+			KDF:   "scrypt",
+			N:     scryptN,
+			R:     scryptR,
+			P:     scryptP,
+			Salt:  salt,
+			Nonce: nonce,
+		},
+		Ciphertext: block.Seal(nil, nonce, plaintext, nil),
+	}
+
+	out, err := json.Marshal(bf)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode bundle")
+	}
+	return ioutil.WriteFile(path, out, 0600)
+}
+
+// ImportBundle decrypts the key bundle at path using passphrase and
+// returns the keys it contains, in the order they were exported.
+func ImportBundle(path, passphrase string) ([]*crypto.KeyInfo, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read key bundle")
+	}
+
+	var bf bundleFile
+	if err := json.Unmarshal(raw, &bf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse key bundle")
+	}
+	if bf.V != bundleVersion {
+		return nil, errors.Errorf("unsupported key bundle version %d", bf.V)
+	}
+	if bf.KDF != "scrypt" {
+		return nil, errors.Errorf("unsupported key bundle KDF %q", bf.KDF)
+	}
+
+	aead, err := newBundleCipherParams(passphrase, bf.Salt, bf.N, bf.R, bf.P)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, bf.Nonce, bf.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt key bundle (wrong passphrase?)")
+	}
+
+	var keys []*crypto.KeyInfo
+	if err := json.Unmarshal(plaintext, &keys); err != nil {
+		return nil, errors.Wrap(err, "failed to decode key bundle contents")
+	}
+	return keys, nil
+}
+
+func newBundleCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	return newBundleCipherParams(passphrase, salt, scryptN, scryptR, scryptP)
+}
+
+func newBundleCipherParams(passphrase string, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, n, r, p, scryptKeyLen)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key from passphrase")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct cipher")
+	}
+	return cipher.NewGCM(block)
+}
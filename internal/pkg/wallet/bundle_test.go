@@ -0,0 +1,113 @@
+package wallet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	k1, err := crypto.NewKeyInfo(crypto.SigTypeSecp256k1)
+	require.NoError(err)
+	k2, err := crypto.NewKeyInfo(crypto.SigTypeBLS)
+	require.NoError(err)
+	keys := []*crypto.KeyInfo{k1, k2}
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(ExportBundle(path, keys, "correct horse battery staple"))
+
+	got, err := ImportBundle(path, "correct horse battery staple")
+	require.NoError(err)
+	require.Len(got, len(keys))
+	for i, ki := range keys {
+		assert.Equal(ki.SigType, got[i].SigType)
+		assert.Equal(ki.PrivateKey, got[i].PrivateKey)
+	}
+}
+
+func TestBundleRoundTripAddressOnlyKey(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	addr, err := crypto.NewKeyInfo(crypto.SigTypeSecp256k1)
+	require.NoError(err)
+	wantAddr, err := addr.Address()
+	require.NoError(err)
+
+	k, err := crypto.NewAddressOnlyKeyInfo(wantAddr)
+	require.NoError(err)
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(ExportBundle(path, []*crypto.KeyInfo{k}, "correct horse battery staple"))
+
+	got, err := ImportBundle(path, "correct horse battery staple")
+	require.NoError(err)
+	require.Len(got, 1)
+
+	gotAddr, err := got[0].Address()
+	require.NoError(err)
+	assert.Equal(wantAddr, gotAddr)
+}
+
+func TestBundleWrongPassphrase(t *testing.T) {
+	require := require.New(t)
+
+	k1, err := crypto.NewKeyInfo(crypto.SigTypeSecp256k1)
+	require.NoError(err)
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(ExportBundle(path, []*crypto.KeyInfo{k1}, "right passphrase"))
+
+	_, err = ImportBundle(path, "wrong passphrase")
+	require.Error(err)
+}
+
+func TestBundleRejectsUnsupportedVersion(t *testing.T) {
+	require := require.New(t)
+
+	k1, err := crypto.NewKeyInfo(crypto.SigTypeSecp256k1)
+	require.NoError(err)
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(ExportBundle(path, []*crypto.KeyInfo{k1}, "a passphrase"))
+
+	bf := readRawBundleFile(t, path)
+	bf.V = bundleVersion + 1
+	writeRawBundleFile(t, path, bf)
+
+	_, err = ImportBundle(path, "a passphrase")
+	require.Error(err)
+
+	bf = readRawBundleFile(t, path)
+	bf.V = bundleVersion
+	bf.KDF = "argon2"
+	writeRawBundleFile(t, path, bf)
+
+	_, err = ImportBundle(path, "a passphrase")
+	require.Error(err)
+}
+
+func readRawBundleFile(t *testing.T, path string) bundleFile {
+	t.Helper()
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var bf bundleFile
+	require.NoError(t, json.Unmarshal(raw, &bf))
+	return bf
+}
+
+func writeRawBundleFile(t *testing.T, path string, bf bundleFile) {
+	t.Helper()
+	raw, err := json.Marshal(bf)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, raw, 0600))
+}
@@ -0,0 +1,124 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+)
+
+// DSBackend is the default wallet Backend: every KeyInfo it is given
+// is stored directly in a datastore, keyed by address.
+type DSBackend struct {
+	lk sync.RWMutex
+	ds datastore.Batching
+}
+
+var _ Backend = (*DSBackend)(nil)
+
+// NewDSBackend creates a DSBackend backed by ds.
+func NewDSBackend(ds datastore.Batching) (*DSBackend, error) {
+	return &DSBackend{ds: ds}, nil
+}
+
+// Import stores ki, keyed by its address. ctx is accepted to satisfy
+// Backend; the underlying datastore has no cancellation of its own.
+func (b *DSBackend) Import(ctx context.Context, ki *crypto.KeyInfo) (address.Address, error) {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	addr, err := ki.Address()
+	if err != nil {
+		return address.Undef, errors.Wrap(err, "failed to derive address for key")
+	}
+
+	raw, err := json.Marshal(ki)
+	if err != nil {
+		return address.Undef, errors.Wrap(err, "failed to encode key")
+	}
+	if err := b.ds.Put(dsKeyForAddress(addr), raw); err != nil {
+		return address.Undef, errors.Wrap(err, "failed to store key")
+	}
+	return addr, nil
+}
+
+// List returns the addresses of every key stored in the backend.
+func (b *DSBackend) List(ctx context.Context) ([]address.Address, error) {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	res, err := b.ds.Query(dsq.Query{KeysOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query wallet datastore")
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read wallet datastore")
+	}
+
+	addrs := make([]address.Address, 0, len(entries))
+	for _, entry := range entries {
+		addr, err := address.NewFromString(strings.TrimPrefix(entry.Key, "/"))
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// HasKey returns whether the backend holds key material for addr.
+func (b *DSBackend) HasKey(ctx context.Context, addr address.Address) bool {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	has, err := b.ds.Has(dsKeyForAddress(addr))
+	return err == nil && has
+}
+
+// Sign signs data with the key stored for addr.
+func (b *DSBackend) Sign(ctx context.Context, addr address.Address, data []byte) (crypto.Signature, error) {
+	ki, err := b.keyInfoFor(addr)
+	if err != nil {
+		return crypto.Signature{}, err
+	}
+	return ki.Sign(data)
+}
+
+// NewKeyInfo generates a new key of sigType and stores it.
+func (b *DSBackend) NewKeyInfo(ctx context.Context, sigType crypto.SigType) (*crypto.KeyInfo, error) {
+	ki, err := crypto.NewKeyInfo(sigType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+	if _, err := b.Import(ctx, ki); err != nil {
+		return nil, err
+	}
+	return ki, nil
+}
+
+func (b *DSBackend) keyInfoFor(addr address.Address) (*crypto.KeyInfo, error) {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	raw, err := b.ds.Get(dsKeyForAddress(addr))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load key for address %s", addr)
+	}
+	var ki crypto.KeyInfo
+	if err := json.Unmarshal(raw, &ki); err != nil {
+		return nil, errors.Wrap(err, "failed to decode key")
+	}
+	return &ki, nil
+}
+
+func dsKeyForAddress(addr address.Address) datastore.Key {
+	return datastore.NewKey(addr.String())
+}
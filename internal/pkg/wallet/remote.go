@@ -0,0 +1,119 @@
+package wallet
+
+import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+	"github.com/pkg/errors"
+	"github.com/ybbus/jsonrpc"
+)
+
+// defaultRPCTimeout bounds every call to the remote signer, so a hung
+// or network-partitioned signer can't block a caller (e.g. one on the
+// block/message signing path) indefinitely even if it passes a
+// context.Context with no deadline of its own.
+const defaultRPCTimeout = 30 * time.Second
+
+// RemoteBackend is a Backend whose keys are held by an external signer
+// process (for example a key custodian on an HSM, or a lotus-wallet-style
+// sidecar) and reached over JSON-RPC. No private key material is ever
+// copied into the local repo: Import, List and Sign are all proxied to
+// the remote endpoint, and NewKeyInfo returns a KeyInfo with only the
+// address populated.
+type RemoteBackend struct {
+	endpoint string
+	token    string
+	client   jsonrpc.RPCClient
+}
+
+var _ Backend = (*RemoteBackend)(nil)
+
+// NewRemoteBackend returns a RemoteBackend for the remote signer at
+// endpoint, authenticating requests with the bearer token. It does not
+// contact the signer; the first request is made by the first method
+// call.
+func NewRemoteBackend(endpoint, token string) (*RemoteBackend, error) {
+	client := jsonrpc.NewClientWithOpts(endpoint, &jsonrpc.RPCClientOpts{
+		CustomHeaders: map[string]string{
+			"Authorization": "Bearer " + token,
+		},
+	})
+	return &RemoteBackend{
+		endpoint: endpoint,
+		token:    token,
+		client:   client,
+	}, nil
+}
+
+// Endpoint returns the remote signer's JSON-RPC endpoint, so it can be
+// persisted in the repo config and reused on the next boot.
+func (rb *RemoteBackend) Endpoint() string {
+	return rb.endpoint
+}
+
+// Import asks the remote signer to import ki and report its address.
+// The signer, not the local repo, retains the private key material.
+func (rb *RemoteBackend) Import(ctx context.Context, ki *crypto.KeyInfo) (address.Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	var addr address.Address
+	if err := rb.client.CallFor(ctx, &addr, "WalletImport", ki); err != nil {
+		return address.Undef, errors.Wrap(err, "remote signer rejected import")
+	}
+	return addr, nil
+}
+
+// List returns the addresses known to the remote signer.
+func (rb *RemoteBackend) List(ctx context.Context) ([]address.Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	var addrs []address.Address
+	if err := rb.client.CallFor(ctx, &addrs, "WalletList"); err != nil {
+		return nil, errors.Wrap(err, "failed to list remote signer keys")
+	}
+	return addrs, nil
+}
+
+// HasKey asks the remote signer whether it holds key material for addr.
+func (rb *RemoteBackend) HasKey(ctx context.Context, addr address.Address) bool {
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	var has bool
+	if err := rb.client.CallFor(ctx, &has, "WalletHas", addr); err != nil {
+		return false
+	}
+	return has
+}
+
+// Sign asks the remote signer to sign data with the key for addr. The
+// private key never leaves the remote process.
+func (rb *RemoteBackend) Sign(ctx context.Context, addr address.Address, data []byte) (crypto.Signature, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	var sig crypto.Signature
+	if err := rb.client.CallFor(ctx, &sig, "WalletSign", addr, data); err != nil {
+		return crypto.Signature{}, errors.Wrap(err, "remote signer rejected sign request")
+	}
+	return sig, nil
+}
+
+// NewKeyInfo asks the remote signer to generate a new key of sigType.
+// Since the private key is never exported, the returned KeyInfo carries
+// only the resulting address; callers must not assume KeyInfo.Key() is
+// populated for keys produced by a RemoteBackend.
+func (rb *RemoteBackend) NewKeyInfo(ctx context.Context, sigType crypto.SigType) (*crypto.KeyInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	var addr address.Address
+	if err := rb.client.CallFor(ctx, &addr, "WalletNew", sigType); err != nil {
+		return nil, errors.Wrap(err, "remote signer rejected key generation")
+	}
+	return crypto.NewAddressOnlyKeyInfo(addr)
+}
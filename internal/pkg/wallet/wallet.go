@@ -0,0 +1,50 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/address"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+)
+
+// Wallet manages a node's keys, delegating storage and signing to a
+// single Backend. The default backend is a local DSBackend; BackendOpt
+// and RemoteBackendOpt (see node.Init) select a different one.
+type Wallet struct {
+	backend Backend
+}
+
+// New creates a Wallet whose keys are stored and signed for by backend.
+func New(backend Backend) *Wallet {
+	return &Wallet{backend: backend}
+}
+
+// Import adds ki to the wallet's backend and returns its address.
+func (w *Wallet) Import(ctx context.Context, ki *crypto.KeyInfo) (address.Address, error) {
+	return w.backend.Import(ctx, ki)
+}
+
+// NewKeyInfo generates a new key of sigType in the wallet's backend.
+func (w *Wallet) NewKeyInfo(ctx context.Context, sigType crypto.SigType) (*crypto.KeyInfo, error) {
+	return w.backend.NewKeyInfo(ctx, sigType)
+}
+
+// Addresses returns every address known to the wallet's backend.
+func (w *Wallet) Addresses(ctx context.Context) []address.Address {
+	addrs, err := w.backend.List(ctx)
+	if err != nil {
+		return nil
+	}
+	return addrs
+}
+
+// HasAddress returns whether the wallet's backend holds key material
+// for addr.
+func (w *Wallet) HasAddress(ctx context.Context, addr address.Address) bool {
+	return w.backend.HasKey(ctx, addr)
+}
+
+// SignBytes signs data with the key for addr.
+func (w *Wallet) SignBytes(ctx context.Context, addr address.Address, data []byte) (crypto.Signature, error) {
+	return w.backend.Sign(ctx, addr, data)
+}